@@ -0,0 +1,77 @@
+// Package appconfig loads the multi-app configuration file that replaces the
+// single PACKAGE_NAME/KEY_PATH environment variables, letting one process
+// track several Play Store apps at once.
+package appconfig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/wilik16/google-play-android-review-fetcher/notifier"
+	"github.com/wilik16/google-play-android-review-fetcher/review"
+)
+
+// App describes one Play Store app to poll.
+type App struct {
+	ID          string              `yaml:"id" json:"id"`
+	PackageName string              `yaml:"packageName" json:"packageName"`
+	KeyPath     string              `yaml:"keyPath" json:"keyPath"`
+	Notifiers   []string            `yaml:"notifiers" json:"notifiers"`
+	Notifier    notifier.Config     `yaml:"notifier" json:"notifier"`
+	RatingRange *review.RatingRange `yaml:"ratingRange,omitempty" json:"ratingRange,omitempty"`
+	StoreDriver string              `yaml:"storeDriver" json:"storeDriver"`
+	StoreDSN    string              `yaml:"storeDSN" json:"storeDSN"`
+}
+
+// Config is the top-level shape of the apps config file.
+type Config struct {
+	// Concurrency bounds how many apps are polled at the same time.
+	// Defaults to 4 when unset.
+	Concurrency int   `yaml:"concurrency" json:"concurrency"`
+	Apps        []App `yaml:"apps" json:"apps"`
+}
+
+// Load reads and parses a YAML or JSON apps config file, based on its
+// extension.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read apps config: %v", err)
+	}
+
+	var cfg Config
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &cfg); err != nil {
+			return Config{}, fmt.Errorf("failed to parse apps config: %v", err)
+		}
+	case ".json":
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return Config{}, fmt.Errorf("failed to parse apps config: %v", err)
+		}
+	default:
+		return Config{}, fmt.Errorf("unsupported apps config extension %q", ext)
+	}
+
+	if len(cfg.Apps) == 0 {
+		return Config{}, fmt.Errorf("apps config must declare at least one app")
+	}
+	for i, app := range cfg.Apps {
+		if app.ID == "" {
+			return Config{}, fmt.Errorf("apps[%d]: id is required", i)
+		}
+		if app.PackageName == "" {
+			return Config{}, fmt.Errorf("apps[%d] (%s): packageName is required", i, app.ID)
+		}
+	}
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 4
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,114 @@
+// Package classifier scores review text with a simple lexicon-based
+// sentiment score and attaches keyword labels (e.g. "crash", "login",
+// "payment") that the rules package routes on.
+package classifier
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Result holds what a Classify call attaches to a review.
+type Result struct {
+	Sentiment string
+	Labels    []string
+}
+
+// defaultKeywords maps a label to the regex that detects it in review text.
+var defaultKeywords = map[string]string{
+	"crash":   `(?i)\bcrash(es|ed|ing)?\b`,
+	"login":   `(?i)\blog[ -]?in\b`,
+	"payment": `(?i)\b(payment|charge[ds]?|refund(ed)?|billing)\b`,
+}
+
+var positiveWords = map[string]bool{
+	"great": true, "love": true, "loved": true, "excellent": true,
+	"amazing": true, "good": true, "best": true, "awesome": true, "perfect": true,
+}
+
+var negativeWords = map[string]bool{
+	"bad": true, "worst": true, "terrible": true, "hate": true, "hated": true,
+	"awful": true, "broken": true, "useless": true, "horrible": true,
+}
+
+// Classifier scores review text against a compiled set of keyword regexes.
+type Classifier struct {
+	keywords map[string]*regexp.Regexp
+}
+
+// New compiles a Classifier from keywords (label -> regex pattern). A nil or
+// empty map falls back to the built-in defaults.
+func New(keywords map[string]string) (*Classifier, error) {
+	if len(keywords) == 0 {
+		keywords = defaultKeywords
+	}
+
+	c := &Classifier{keywords: make(map[string]*regexp.Regexp, len(keywords))}
+	for label, pattern := range keywords {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid keyword pattern for label %q: %v", label, err)
+		}
+		c.keywords[label] = re
+	}
+	return c, nil
+}
+
+// config is the on-disk shape of a classifier.yaml file: a flat label ->
+// regex pattern map, same shape New takes directly.
+type config map[string]string
+
+// Load reads and compiles a classifier.yaml file of label -> regex pattern
+// overrides, letting an operator replace the built-in crash/login/payment
+// keywords without a code change.
+func Load(path string) (*Classifier, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read classifier config: %v", err)
+	}
+
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse classifier config: %v", err)
+	}
+
+	return New(cfg)
+}
+
+// Classify attaches every matching keyword label and scores text's
+// sentiment as "positive", "negative", or "neutral".
+func (c *Classifier) Classify(text string) Result {
+	var labels []string
+	for label, re := range c.keywords {
+		if re.MatchString(text) {
+			labels = append(labels, label)
+		}
+	}
+	sort.Strings(labels)
+
+	score := 0
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		word = strings.Trim(word, ".,!?;:\"'")
+		switch {
+		case positiveWords[word]:
+			score++
+		case negativeWords[word]:
+			score--
+		}
+	}
+
+	sentiment := "neutral"
+	switch {
+	case score > 0:
+		sentiment = "positive"
+	case score < 0:
+		sentiment = "negative"
+	}
+
+	return Result{Sentiment: sentiment, Labels: labels}
+}
@@ -2,31 +2,47 @@ package main
 
 import (
 	"context"
-	"encoding/csv"
-	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/joho/godotenv"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/androidpublisher/v3"
 	"google.golang.org/api/option"
+
+	"github.com/wilik16/google-play-android-review-fetcher/appconfig"
+	"github.com/wilik16/google-play-android-review-fetcher/classifier"
+	"github.com/wilik16/google-play-android-review-fetcher/metrics"
+	"github.com/wilik16/google-play-android-review-fetcher/notifier"
+	"github.com/wilik16/google-play-android-review-fetcher/reply"
+	"github.com/wilik16/google-play-android-review-fetcher/replyserver"
+	"github.com/wilik16/google-play-android-review-fetcher/rules"
+	"github.com/wilik16/google-play-android-review-fetcher/runner"
+	"github.com/wilik16/google-play-android-review-fetcher/store"
 )
 
 // Configuration
 var (
-	packageName string
-	webhookURL  string
-	keyPath     string
-	reviewsCSV  string
-	logDir      string
-	testMode    bool
+	appsConfigPath       string
+	rulesConfigPath      string
+	classifierConfigPath string
+	logDir               string
+	testMode             bool
+	pollInterval         time.Duration
+	notifierCfg          notifier.Config
+	storeDriver          string
+	storeDSN             string
+	replyServerAddr      string
+	metricsAddr          string
 )
 
 // getEnv gets an environment variable or returns a default value
@@ -48,24 +64,49 @@ func getEnvBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
-// Review represents a single review from the Play Store
-type Review struct {
-	ReviewID    string    `json:"reviewId"`
-	Rating      int       `json:"rating"`
-	Text        string    `json:"text"`
-	Author      string    `json:"author"`
-	Device      string    `json:"device"`
-	Date        time.Time `json:"date"`
-	Notified    bool      `json:"notified"`
+// getEnvDuration gets a duration environment variable (seconds) or returns a
+// default value. For POLL_INTERVAL, a value of 0 disables polling and runs a
+// single pass.
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		seconds, err := strconv.Atoi(value)
+		if err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return defaultValue
 }
 
-// RatingRange represents a range of ratings to filter
-type RatingRange struct {
-	Start int
-	End   int
+// getEnvInt gets an integer environment variable or returns a default value.
+func getEnvInt(key string, defaultValue int) int {
+	if value, exists := os.LookupEnv(key); exists {
+		n, err := strconv.Atoi(value)
+		if err == nil {
+			return n
+		}
+	}
+	return defaultValue
 }
 
-// setupLogging initializes the logging system
+// getEnvList splits a comma-separated environment variable into a trimmed,
+// non-empty slice of values.
+func getEnvList(key string) []string {
+	value, exists := os.LookupEnv(key)
+	if !exists || value == "" {
+		return nil
+	}
+	var out []string
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// setupLogging opens the daily log file and installs it as the destination
+// for the default structured (JSON) logger.
 func setupLogging() (*os.File, error) {
 	if err := os.MkdirAll(logDir, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create log directory: %v", err)
@@ -81,275 +122,418 @@ func setupLogging() (*os.File, error) {
 		return nil, fmt.Errorf("failed to open log file: %v", err)
 	}
 
-	log.SetOutput(logFile)
+	slog.SetDefault(slog.New(slog.NewJSONHandler(logFile, nil)))
 	return logFile, nil
 }
 
-// parseRatingRange parses the rating range from command line arguments
-func parseRatingRange() (*RatingRange, error) {
-	if len(os.Args) < 2 {
-		return nil, nil
-	}
+// fatal logs msg at error level and exits, replacing the old log.Fatalf
+// calls now that the default logger writes JSON instead of plain text.
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
 
-	parts := strings.Split(os.Args[1], "-")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("invalid rating range format. Use format: start-end (e.g., 1-3)")
+// newAndroidPublisherService builds an Android Publisher client from the
+// service account credentials at keyPath.
+func newAndroidPublisherService(ctx context.Context, keyPath string) (*androidpublisher.Service, error) {
+	credentials, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials file: %v", err)
 	}
 
-	start, err := strconv.Atoi(parts[0])
+	config, err := google.JWTConfigFromJSON(credentials, androidpublisher.AndroidpublisherScope)
 	if err != nil {
-		return nil, fmt.Errorf("invalid start rating: %v", err)
+		return nil, fmt.Errorf("failed to create JWT config: %v", err)
 	}
 
-	end, err := strconv.Atoi(parts[1])
-	if err != nil {
-		return nil, fmt.Errorf("invalid end rating: %v", err)
+	return androidpublisher.NewService(ctx, option.WithHTTPClient(config.Client(ctx)))
+}
+
+// appStoreDSN resolves the effective store driver/DSN for app, falling back
+// to the process-wide defaults. A csv or sqlite driver with no DSN of its
+// own gets a per-app file, so apps never clobber each other's data; a
+// Postgres driver has no file path to namespace by app, so an explicit
+// per-app storeDSN is required whenever more than one app is configured,
+// to avoid co-mingling every app's reviews in the one shared table.
+func appStoreDSN(app appconfig.App, numApps int) (driver, dsn string, err error) {
+	driver = app.StoreDriver
+	if driver == "" {
+		driver = storeDriver
+	}
+	dsn = app.StoreDSN
+	if dsn != "" {
+		return driver, dsn, nil
+	}
+	switch driver {
+	case "", "csv":
+		dsn = fmt.Sprintf("reviews-%s.csv", app.ID)
+	case "sqlite":
+		dsn = fmt.Sprintf("reviews-%s.db", app.ID)
+	case "postgres":
+		if numApps > 1 {
+			return "", "", fmt.Errorf("app %s: storeDSN must be set per app in apps.yaml when running multiple apps against postgres, to avoid co-mingling reviews in one table", app.ID)
+		}
+		dsn = storeDSN
 	}
+	return driver, dsn, nil
+}
 
-	if start < 1 || start > 5 || end < 1 || end > 5 {
-		return nil, fmt.Errorf("rating range must be between 1 and 5")
+// buildStore opens app's configured store backend. Callers must share the
+// single instance this returns between the poll loop and the reply server
+// for the same app: CSVStore keeps its data in an in-memory map and rewrites
+// the whole file on every Upsert, so two independent instances pointed at
+// the same file silently clobber each other's writes.
+func buildStore(app appconfig.App, numApps int) (store.ReviewStore, error) {
+	driver, dsn, err := appStoreDSN(app, numApps)
+	if err != nil {
+		return nil, err
+	}
+	reviewStore, err := store.New(driver, dsn, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("app %s: failed to configure store: %v", app.ID, err)
 	}
+	return reviewStore, nil
+}
 
-	if start > end {
-		return nil, fmt.Errorf("start rating must be less than or equal to end rating")
+// buildReplyConfig creates the Android Publisher service for app and wraps
+// it and reviewStore in a reply.Config.
+func buildReplyConfig(ctx context.Context, app appconfig.App, reviewStore store.ReviewStore) (reply.Config, error) {
+	service, err := newAndroidPublisherService(ctx, app.KeyPath)
+	if err != nil {
+		return reply.Config{}, fmt.Errorf("app %s: failed to create Android Publisher service: %v", app.ID, err)
 	}
 
-	return &RatingRange{Start: start, End: end}, nil
+	return reply.Config{Service: service, PackageName: app.PackageName, Store: reviewStore}, nil
 }
 
-// readExistingReviews reads existing reviews from CSV file
-func readExistingReviews() (map[string]Review, error) {
-	reviews := make(map[string]Review)
+func main() {
+	// Load .env file if it exists
+	if err := godotenv.Load(); err != nil {
+		slog.Warn(".env file not found", "error", err)
+	} else {
+		slog.Info("loaded .env file")
+	}
 
-	if _, err := os.Stat(reviewsCSV); os.IsNotExist(err) {
-		return reviews, nil
+	// Initialize configuration
+	appsConfigPath = getEnv("APPS_CONFIG", "apps.yaml")
+	rulesConfigPath = getEnv("RULES_CONFIG", "")
+	classifierConfigPath = getEnv("CLASSIFIER_CONFIG", "")
+	logDir = getEnv("LOG_DIR", "./logs")
+	testMode = getEnvBool("TEST_MODE", true)
+	pollInterval = getEnvDuration("POLL_INTERVAL", 0)
+	storeDriver = getEnv("STORE_DRIVER", "csv")
+	storeDSN = getEnv("STORE_DSN", "")
+	replyServerAddr = getEnv("REPLY_SERVER_ADDR", "")
+	metricsAddr = getEnv("METRICS_ADDR", "")
+
+	// notifierCfg holds the process-wide notifier defaults (shared
+	// credentials such as SMTP login); each app's notifier config in
+	// apps.yaml is merged on top of it, overriding only what it sets.
+	notifierCfg = notifier.Config{
+		GoogleChatWebhookURL: getEnv("WEBHOOK_URL", "https://chat.googleapis.com/v1/spaces/XXXXX/messages?key=YOUR_KEY&token=YOUR_TOKEN"),
+		SlackWebhookURL:      getEnv("SLACK_WEBHOOK_URL", ""),
+		DiscordWebhookURL:    getEnv("DISCORD_WEBHOOK_URL", ""),
+		TeamsWebhookURL:      getEnv("TEAMS_WEBHOOK_URL", ""),
+		SMTPHost:             getEnv("SMTP_HOST", ""),
+		SMTPPort:             getEnvInt("SMTP_PORT", 587),
+		SMTPUsername:         getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:         getEnv("SMTP_PASSWORD", ""),
+		EmailFrom:            getEnv("EMAIL_FROM", ""),
+		EmailTo:              getEnvList("EMAIL_TO"),
+		MaxAttempts:          getEnvInt("NOTIFY_MAX_ATTEMPTS", 3),
+		BaseDelay:            getEnvDuration("NOTIFY_BASE_DELAY", 2*time.Second),
+		ReplyCallbackURL:     getEnv("REPLY_CALLBACK_URL", ""),
+		ReplySigningSecret:   getEnv("REPLY_SIGNING_SECRET", ""),
+		TestMode:             testMode,
+	}
+
+	// "reply <appId> <reviewId> <text>" posts a developer reply and exits,
+	// instead of entering the poll loop.
+	if len(os.Args) > 1 && os.Args[1] == "reply" {
+		runReply()
+		return
+	}
+
+	// "query <appId> [rating=N] [label=X] [since=RFC3339] [until=RFC3339]
+	// [notified=true|false]" lists stored reviews matching the given
+	// filters and exits, instead of entering the poll loop.
+	if len(os.Args) > 1 && os.Args[1] == "query" {
+		runQuery()
+		return
 	}
 
-	file, err := os.Open(reviewsCSV)
+	// Setup logging
+	logFile, err := setupLogging()
 	if err != nil {
-		return nil, fmt.Errorf("failed to open CSV file: %v", err)
+		fatal("failed to setup logging", "error", err)
 	}
-	defer file.Close()
+	defer logFile.Close()
 
-	reader := csv.NewReader(file)
-	records, err := reader.ReadAll()
+	appsCfg, err := appconfig.Load(appsConfigPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read CSV: %v", err)
-	}
-
-	// Skip header
-	for _, record := range records[1:] {
-		date, _ := time.Parse(time.RFC3339, record[5])
-		notified, _ := strconv.ParseBool(record[6])
-		rating, _ := strconv.Atoi(record[1])
-
-		reviews[record[0]] = Review{
-			ReviewID: record[0],
-			Rating:   rating,
-			Text:     record[2],
-			Author:   record[3],
-			Device:   record[4],
-			Date:     date,
-			Notified: notified,
-		}
+		fatal("failed to load apps config", "path", appsConfigPath, "error", err)
 	}
 
-	return reviews, nil
-}
+	var reviewClassifier *classifier.Classifier
+	if classifierConfigPath != "" {
+		reviewClassifier, err = classifier.Load(classifierConfigPath)
+		if err != nil {
+			fatal("failed to load classifier config", "path", classifierConfigPath, "error", err)
+		}
+	} else {
+		reviewClassifier, err = classifier.New(nil)
+		if err != nil {
+			fatal("failed to build classifier", "error", err)
+		}
+	}
 
-// saveReviews saves reviews to CSV file
-func saveReviews(reviews []Review) error {
-	file, err := os.Create(reviewsCSV)
-	if err != nil {
-		return fmt.Errorf("failed to create CSV file: %v", err)
+	var routingRules *rules.Engine
+	if rulesConfigPath != "" {
+		routingRules, err = rules.Load(rulesConfigPath)
+		if err != nil {
+			fatal("failed to load rules config", "path", rulesConfigPath, "error", err)
+		}
 	}
-	defer file.Close()
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	// Write header
-	header := []string{"Review ID", "Rating", "Review Text", "Author", "Device", "Date", "Notified"}
-	if err := writer.Write(header); err != nil {
-		return fmt.Errorf("failed to write header: %v", err)
+	if pollInterval > 0 {
+		slog.Info("starting poll loop", "interval", pollInterval, "apps", len(appsCfg.Apps))
+	} else {
+		slog.Info("POLL_INTERVAL not set, running a single pass", "apps", len(appsCfg.Apps))
 	}
 
-	// Write records
-	for _, review := range reviews {
-		record := []string{
-			review.ReviewID,
-			strconv.Itoa(review.Rating),
-			review.Text,
-			review.Author,
-			review.Device,
-			review.Date.Format(time.RFC3339),
-			strconv.FormatBool(review.Notified),
-		}
-		if err := writer.Write(record); err != nil {
-			return fmt.Errorf("failed to write record: %v", err)
+	if metricsAddr != "" {
+		srv := &http.Server{Addr: metricsAddr, Handler: metrics.Handler()}
+		go func() {
+			slog.Info("starting metrics server", "addr", metricsAddr)
+			if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				slog.Error("metrics server failed", "error", err)
+			}
+		}()
+		go func() {
+			<-ctx.Done()
+			srv.Shutdown(context.Background())
+		}()
+	}
+
+	// appStores holds one ReviewStore per app, shared between that app's poll
+	// loop and the reply server so they never hold independent, diverging
+	// snapshots of the same underlying file/DSN (see buildStore).
+	appStores := make(map[string]store.ReviewStore, len(appsCfg.Apps))
+	for _, app := range appsCfg.Apps {
+		reviewStore, err := buildStore(app, len(appsCfg.Apps))
+		if err != nil {
+			fatal("failed to configure store", "appId", app.ID, "error", err)
 		}
+		appStores[app.ID] = reviewStore
 	}
 
-	return nil
+	if replyServerAddr != "" {
+		replyConfigs := make(map[string]reply.Config, len(appsCfg.Apps))
+		for _, app := range appsCfg.Apps {
+			cfg, err := buildReplyConfig(ctx, app, appStores[app.ID])
+			if err != nil {
+				fatal("failed to configure reply server", "appId", app.ID, "error", err)
+			}
+			replyConfigs[app.ID] = cfg
+		}
+
+		srv := &replyserver.Server{Addr: replyServerAddr, Configs: replyConfigs, Secret: notifierCfg.ReplySigningSecret}
+		go func() {
+			slog.Info("starting reply callback server", "addr", replyServerAddr)
+			if err := srv.ListenAndServe(ctx); err != nil {
+				slog.Error("reply callback server failed", "error", err)
+			}
+		}()
+	}
+
+	// Poll every configured app concurrently, bounded by appsCfg.Concurrency,
+	// so one app's backoff or a slow webhook never stalls the others.
+	sem := make(chan struct{}, appsCfg.Concurrency)
+	var wg sync.WaitGroup
+	for _, app := range appsCfg.Apps {
+		app := app
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := runApp(ctx, app, reviewClassifier, routingRules, appStores[app.ID]); err != nil {
+				slog.Error("runner failed", "appId", app.ID, "error", err)
+			}
+		}()
+	}
+	wg.Wait()
 }
 
-// sendToWebhook sends a review to the webhook
-func sendToWebhook(review Review) error {
-	stars := strings.Repeat("⭐", review.Rating)
-	message := map[string]string{
-		"text": fmt.Sprintf("*New Review*\nRating: %s (%d)\nReview: %s\nAuthor: %s\nDevice: %s\nDate: %s",
-			stars, review.Rating, review.Text, review.Author, review.Device, review.Date.Format(time.RFC1123)),
+// runApp builds the Android Publisher client and notifier for a single
+// configured app and runs its poll loop against reviewStore.
+func runApp(ctx context.Context, app appconfig.App, reviewClassifier *classifier.Classifier, routingRules *rules.Engine, reviewStore store.ReviewStore) error {
+	service, err := newAndroidPublisherService(ctx, app.KeyPath)
+	if err != nil {
+		return fmt.Errorf("failed to create Android Publisher service: %v", err)
 	}
 
-	if testMode {
-		log.Printf("TEST MODE - Would send to webhook:\n%s", message["text"])
-		time.Sleep(2 * time.Second)
-		return nil
+	mergedNotifierCfg := notifier.Merge(notifierCfg, app.Notifier)
+	notifierNames := app.Notifiers
+	if len(notifierNames) == 0 {
+		notifierNames = []string{"googlechat"}
 	}
 
-	jsonData, err := json.Marshal(message)
+	notify, err := notifier.New(notifierNames, mergedNotifierCfg)
 	if err != nil {
-		return fmt.Errorf("failed to marshal message: %v", err)
+		return fmt.Errorf("failed to configure notifiers: %v", err)
 	}
 
-	resp, err := http.Post(webhookURL, "application/json", strings.NewReader(string(jsonData)))
+	// namedNotifiers lets routingRules address individual channels by name
+	// instead of always fanning out to every configured notifier.
+	namedNotifiers, err := notifier.NewNamed(notifierNames, mergedNotifierCfg)
 	if err != nil {
-		return fmt.Errorf("failed to send webhook: %v", err)
-	}
-	defer resp.Body.Close()
+		return fmt.Errorf("failed to configure named notifiers: %v", err)
+	}
+
+	return runner.Run(ctx, runner.Config{
+		AppID:          app.ID,
+		Service:        service,
+		PackageName:    app.PackageName,
+		Notifier:       notify,
+		Store:          reviewStore,
+		RatingRange:    app.RatingRange,
+		PollInterval:   pollInterval,
+		Classifier:     reviewClassifier,
+		Rules:          routingRules,
+		NamedNotifiers: namedNotifiers,
+		NotifierNames:  notifierNames,
+	})
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("webhook returned non-200 status code: %d", resp.StatusCode)
+// findApp loads the apps config and returns the app with the given ID,
+// exiting via fatal if the config can't be loaded or no app matches.
+func findApp(appID string) appconfig.App {
+	appsCfg, err := appconfig.Load(appsConfigPath)
+	if err != nil {
+		fatal("failed to load apps config", "path", appsConfigPath, "error", err)
 	}
 
-	// Add delay to avoid rate limiting
-	time.Sleep(2 * time.Second)
-	return nil
-}
-
-// cleanText removes leading and trailing whitespace and tabs from text
-func cleanText(text string) string {
-	return strings.TrimSpace(text)
+	for i := range appsCfg.Apps {
+		if appsCfg.Apps[i].ID == appID {
+			return appsCfg.Apps[i]
+		}
+	}
+	fatal("unknown appId", "appId", appID, "path", appsConfigPath)
+	panic("unreachable")
 }
 
-func main() {
-	// Load .env file if it exists
-	if err := godotenv.Load(); err != nil {
-		log.Printf("Warning: .env file not found: %v", err)
-	} else {
-		log.Printf("Successfully loaded .env file")
+// runReply handles the "reply <appId> <reviewId> <text>" subcommand: it
+// looks up appId in the apps config, posts a developer reply to the Play
+// Store, and persists the result to that app's store.
+func runReply() {
+	if len(os.Args) < 5 {
+		fatal("usage: reply <appId> <reviewId> <text>", "arg0", os.Args[0])
 	}
+	appID := os.Args[2]
+	reviewID := os.Args[3]
+	text := strings.Join(os.Args[4:], " ")
 
-	// Initialize configuration
-	packageName = getEnv("PACKAGE_NAME", "com.example.app")
-	webhookURL = getEnv("WEBHOOK_URL", "https://chat.googleapis.com/v1/spaces/XXXXX/messages?key=YOUR_KEY&token=YOUR_TOKEN")
-	keyPath = getEnv("KEY_PATH", "./service-account.json")
-	reviewsCSV = getEnv("REVIEWS_CSV", "reviews.csv")
-	logDir = getEnv("LOG_DIR", "./logs")
-	testMode = getEnvBool("TEST_MODE", true)
+	app := findApp(appID)
 
-	// Setup logging
-	logFile, err := setupLogging()
+	ctx := context.Background()
+
+	reviewStore, err := buildStore(app, 1)
 	if err != nil {
-		log.Fatalf("Failed to setup logging: %v", err)
+		fatal("failed to configure store", "error", err)
 	}
-	defer logFile.Close()
 
-	// Parse rating range
-	ratingRange, err := parseRatingRange()
+	cfg, err := buildReplyConfig(ctx, app, reviewStore)
 	if err != nil {
-		log.Fatalf("Failed to parse rating range: %v", err)
-	}
-	if ratingRange != nil {
-		log.Printf("Filtering reviews with ratings from %d to %d", ratingRange.Start, ratingRange.End)
+		fatal("failed to configure reply", "error", err)
 	}
 
-	// Initialize Google API client
-	ctx := context.Background()
-	credentials, err := os.ReadFile(keyPath)
+	r, err := reply.Send(ctx, cfg, reviewID, text)
 	if err != nil {
-		log.Fatalf("Failed to read credentials file: %v", err)
+		fatal("failed to reply to review", "reviewId", reviewID, "error", err)
 	}
 
-	config, err := google.JWTConfigFromJSON(credentials, androidpublisher.AndroidpublisherScope)
-	if err != nil {
-		log.Fatalf("Failed to create JWT config: %v", err)
+	fmt.Printf("Replied to review %s (app %s): %q\n", r.ReviewID, appID, r.DeveloperComment)
+}
+
+// runQuery handles the "query <appId> [rating=N] [label=X] [since=RFC3339]
+// [until=RFC3339] [notified=true|false]" subcommand: it looks up appId in
+// the apps config and lists every stored review matching the given filters
+// via the store's ListFiltered (indexed for sqlite/postgres, a linear scan
+// for csv).
+// label may be repeated to require more than one label.
+func runQuery() {
+	if len(os.Args) < 3 {
+		fatal("usage: query <appId> [rating=N] [label=X] [since=RFC3339] [until=RFC3339] [notified=true|false]", "arg0", os.Args[0])
 	}
+	appID := os.Args[2]
 
-	service, err := androidpublisher.NewService(ctx, option.WithHTTPClient(config.Client(ctx)))
+	f, err := parseQueryFilter(os.Args[3:])
 	if err != nil {
-		log.Fatalf("Failed to create Android Publisher service: %v", err)
+		fatal("invalid query filter", "error", err)
 	}
 
-	// Get existing reviews
-	existingReviews, err := readExistingReviews()
+	app := findApp(appID)
+
+	reviewStore, err := buildStore(app, 1)
 	if err != nil {
-		log.Fatalf("Failed to read existing reviews: %v", err)
+		fatal("failed to configure store", "error", err)
 	}
-	log.Printf("Found %d existing reviews in CSV", len(existingReviews))
 
-	// Fetch new reviews
-	reviews, err := service.Reviews.List(packageName).Do()
+	reviews, err := reviewStore.ListFiltered(context.Background(), f)
 	if err != nil {
-		log.Printf("Failed to fetch reviews: %v", err)
-		log.Printf("packageName: %s", packageName)
-		log.Fatalf("Failed to fetch reviews: %v", err)
-	}
-	log.Printf("Total reviews fetched from API: %d", len(reviews.Reviews))
-
-	// Process new reviews
-	var newReviews []Review
-	for _, review := range reviews.Reviews {
-		rating := int(review.Comments[0].UserComment.StarRating)
-		if ratingRange != nil && (rating < ratingRange.Start || rating > ratingRange.End) {
-			continue
-		}
-
-		var device = "Unknown"
-		if review.Comments[0].UserComment.DeviceMetadata != nil {
-			device = review.Comments[0].UserComment.DeviceMetadata.ProductName
-		}
-
-		if existingReview, exists := existingReviews[review.ReviewId]; !exists || !existingReview.Notified {
-			newReview := Review{
-				ReviewID: review.ReviewId,
-				Rating:   rating,
-				Text:     cleanText(review.Comments[0].UserComment.Text),
-				Author:   review.AuthorName,
-				Device:   device,
-				Date:     time.Unix(review.Comments[0].UserComment.LastModified.Seconds, 0),
-				Notified: false,
-			}
-
-			if err := sendToWebhook(newReview); err != nil {
-				log.Printf("Failed to send review %s to webhook: %v", review.ReviewId, err)
-				continue
-			}
-
-			newReview.Notified = true
-			newReviews = append(newReviews, newReview)
-			existingReviews[review.ReviewId] = newReview
-		}
+		fatal("failed to query reviews", "appId", appID, "error", err)
 	}
 
-	// Save all reviews
-	var allReviews []Review
-	for _, review := range existingReviews {
-		allReviews = append(allReviews, review)
-	}
-	if err := saveReviews(allReviews); err != nil {
-		log.Fatalf("Failed to save reviews: %v", err)
+	for _, r := range reviews {
+		fmt.Printf("%s rating=%d notified=%t labels=%s date=%s text=%q\n",
+			r.ReviewID, r.Rating, r.Notified, strings.Join(r.Labels, ","), r.Date.Format(time.RFC3339), r.Text)
 	}
+	fmt.Printf("%d review(s) matched\n", len(reviews))
+}
 
-	// Log results
-	if len(newReviews) > 0 {
-		log.Printf("\n=== New Reviews ===")
-		for i, review := range newReviews {
-			stars := strings.Repeat("⭐", review.Rating)
-			log.Printf("[%d] %s | %s | %s | %s | %s",
-				i+1, stars, review.Text, review.Author, review.Device, review.Date.Format(time.RFC1123))
+// parseQueryFilter parses the "key=value" arguments of the query subcommand
+// into a store.Filter.
+func parseQueryFilter(args []string) (store.Filter, error) {
+	var f store.Filter
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok {
+			return store.Filter{}, fmt.Errorf("expected key=value, got %q", arg)
+		}
+		switch key {
+		case "rating":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return store.Filter{}, fmt.Errorf("invalid rating %q: %v", value, err)
+			}
+			f.Rating = n
+		case "label":
+			f.Labels = append(f.Labels, value)
+		case "since":
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return store.Filter{}, fmt.Errorf("invalid since %q: %v", value, err)
+			}
+			f.Since = t
+		case "until":
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return store.Filter{}, fmt.Errorf("invalid until %q: %v", value, err)
+			}
+			f.Until = t
+		case "notified":
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return store.Filter{}, fmt.Errorf("invalid notified %q: %v", value, err)
+			}
+			f.Notified = &b
+		default:
+			return store.Filter{}, fmt.Errorf("unknown filter %q", key)
 		}
-	} else {
-		log.Println("No new reviews found")
 	}
-} 
\ No newline at end of file
+	return f, nil
+}
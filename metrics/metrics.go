@@ -0,0 +1,68 @@
+// Package metrics exposes the Prometheus collectors the runner and notifier
+// packages record against, plus the /metrics and /healthz HTTP handlers that
+// make the daemon observable.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	// ReviewsFetched counts every review returned by the Play Store API,
+	// before rating-range filtering or dedup against the store.
+	ReviewsFetched = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "review_fetcher_reviews_fetched_total",
+		Help: "Total number of reviews fetched from the Play Store API.",
+	}, []string{"app_id"})
+
+	// ReviewsNotified counts reviews successfully delivered to a notifier
+	// backend, broken down by star rating and notifier name.
+	ReviewsNotified = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "review_fetcher_reviews_notified_total",
+		Help: "Total number of reviews successfully notified.",
+	}, []string{"app_id", "rating", "notifier"})
+
+	// APILatency measures the latency of Play Store API calls.
+	APILatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "review_fetcher_api_latency_seconds",
+		Help:    "Latency of Play Store API calls.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"app_id", "operation"})
+
+	// WebhookLatency measures the latency of outbound notifier webhook
+	// requests, including retried attempts.
+	WebhookLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "review_fetcher_webhook_latency_seconds",
+		Help:    "Latency of outbound notifier webhook requests.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"notifier"})
+
+	// WebhookFailures counts failed notifier webhook attempts.
+	WebhookFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "review_fetcher_webhook_failures_total",
+		Help: "Total number of failed notifier webhook requests.",
+	}, []string{"notifier"})
+
+	// RateLimitSleepSeconds accumulates time spent backing off after
+	// transient Play Store API errors.
+	RateLimitSleepSeconds = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "review_fetcher_rate_limit_sleep_seconds_total",
+		Help: "Total seconds spent sleeping for backoff after API errors.",
+	}, []string{"app_id"})
+)
+
+// Handler serves /metrics (Prometheus exposition format) and /healthz (a
+// plain liveness check).
+func Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("ok"))
+	})
+	return mux
+}
@@ -0,0 +1,88 @@
+package notifier
+
+import "time"
+
+// Config holds per-notifier configuration, populated from environment
+// variables or a per-app config override. Only the fields relevant to the
+// notifiers selected for an app need to be set.
+type Config struct {
+	GoogleChatWebhookURL string `yaml:"googleChatWebhookURL,omitempty" json:"googleChatWebhookURL,omitempty"`
+	SlackWebhookURL      string `yaml:"slackWebhookURL,omitempty" json:"slackWebhookURL,omitempty"`
+	DiscordWebhookURL    string `yaml:"discordWebhookURL,omitempty" json:"discordWebhookURL,omitempty"`
+	TeamsWebhookURL      string `yaml:"teamsWebhookURL,omitempty" json:"teamsWebhookURL,omitempty"`
+
+	SMTPHost     string   `yaml:"smtpHost,omitempty" json:"smtpHost,omitempty"`
+	SMTPPort     int      `yaml:"smtpPort,omitempty" json:"smtpPort,omitempty"`
+	SMTPUsername string   `yaml:"smtpUsername,omitempty" json:"smtpUsername,omitempty"`
+	SMTPPassword string   `yaml:"smtpPassword,omitempty" json:"smtpPassword,omitempty"`
+	EmailFrom    string   `yaml:"emailFrom,omitempty" json:"emailFrom,omitempty"`
+	EmailTo      []string `yaml:"emailTo,omitempty" json:"emailTo,omitempty"`
+
+	MaxAttempts int           `yaml:"maxAttempts,omitempty" json:"maxAttempts,omitempty"`
+	BaseDelay   time.Duration `yaml:"baseDelay,omitempty" json:"baseDelay,omitempty"`
+
+	// ReplyCallbackURL, when set, is included as an action button on the
+	// Slack and Google Chat backends, linking to the replyserver's reply
+	// form for that review.
+	ReplyCallbackURL string `yaml:"replyCallbackURL,omitempty" json:"replyCallbackURL,omitempty"`
+	// ReplySigningSecret, when set, is used to sign the reviewId/appId
+	// embedded in ReplyCallbackURL links so the replyserver can reject
+	// requests that didn't originate from a button it minted. It must match
+	// the replyserver's own secret.
+	ReplySigningSecret string `yaml:"replySigningSecret,omitempty" json:"replySigningSecret,omitempty"`
+
+	TestMode bool `yaml:"testMode,omitempty" json:"testMode,omitempty"`
+}
+
+// Merge returns a copy of base with every non-zero field of override applied
+// on top, so a per-app config only needs to specify the fields it wants to
+// change.
+func Merge(base, override Config) Config {
+	merged := base
+	if override.GoogleChatWebhookURL != "" {
+		merged.GoogleChatWebhookURL = override.GoogleChatWebhookURL
+	}
+	if override.SlackWebhookURL != "" {
+		merged.SlackWebhookURL = override.SlackWebhookURL
+	}
+	if override.DiscordWebhookURL != "" {
+		merged.DiscordWebhookURL = override.DiscordWebhookURL
+	}
+	if override.TeamsWebhookURL != "" {
+		merged.TeamsWebhookURL = override.TeamsWebhookURL
+	}
+	if override.SMTPHost != "" {
+		merged.SMTPHost = override.SMTPHost
+	}
+	if override.SMTPPort != 0 {
+		merged.SMTPPort = override.SMTPPort
+	}
+	if override.SMTPUsername != "" {
+		merged.SMTPUsername = override.SMTPUsername
+	}
+	if override.SMTPPassword != "" {
+		merged.SMTPPassword = override.SMTPPassword
+	}
+	if override.EmailFrom != "" {
+		merged.EmailFrom = override.EmailFrom
+	}
+	if len(override.EmailTo) > 0 {
+		merged.EmailTo = override.EmailTo
+	}
+	if override.MaxAttempts != 0 {
+		merged.MaxAttempts = override.MaxAttempts
+	}
+	if override.BaseDelay != 0 {
+		merged.BaseDelay = override.BaseDelay
+	}
+	if override.ReplyCallbackURL != "" {
+		merged.ReplyCallbackURL = override.ReplyCallbackURL
+	}
+	if override.ReplySigningSecret != "" {
+		merged.ReplySigningSecret = override.ReplySigningSecret
+	}
+	if override.TestMode {
+		merged.TestMode = override.TestMode
+	}
+	return merged
+}
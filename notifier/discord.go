@@ -0,0 +1,52 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wilik16/google-play-android-review-fetcher/review"
+)
+
+// ratingColors maps a star rating to a Discord embed color, red for 1-star
+// down to green for 5-star.
+var ratingColors = map[int]int{
+	1: 0xE74C3C,
+	2: 0xE67E22,
+	3: 0xF1C40F,
+	4: 0x2ECC71,
+	5: 0x27AE60,
+}
+
+// Discord notifies a Discord webhook using a rating-colored embed.
+type Discord struct {
+	WebhookURL string
+	TestMode   bool
+}
+
+// Notify sends r to the configured Discord webhook as an embed.
+func (d *Discord) Notify(ctx context.Context, r review.Review) error {
+	stars := strings.Repeat("⭐", r.Rating)
+	payload := map[string]interface{}{
+		"embeds": []map[string]interface{}{
+			{
+				"title":       fmt.Sprintf("New Review %s", stars),
+				"description": r.Text,
+				"color":       ratingColors[r.Rating],
+				"fields": []map[string]interface{}{
+					{"name": "Author", "value": r.Author, "inline": true},
+					{"name": "Device", "value": r.Device, "inline": true},
+					{"name": "Date", "value": r.Date.Format(time.RFC1123), "inline": false},
+				},
+			},
+		},
+	}
+
+	if d.TestMode {
+		fmt.Printf("TEST MODE - Would send to Discord:\n%+v\n", payload)
+		return nil
+	}
+
+	return postJSON(ctx, d.WebhookURL, payload)
+}
@@ -0,0 +1,45 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/wilik16/google-play-android-review-fetcher/review"
+)
+
+// Email notifies recipients of a new review over SMTP.
+type Email struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+	TestMode bool
+}
+
+// Notify emails r to the configured recipients.
+func (e *Email) Notify(ctx context.Context, r review.Review) error {
+	stars := strings.Repeat("⭐", r.Rating)
+	subject := fmt.Sprintf("New Review %s (%d)", stars, r.Rating)
+	body := fmt.Sprintf("Review: %s\nAuthor: %s\nDevice: %s\nDate: %s",
+		r.Text, r.Author, r.Device, r.Date.Format(time.RFC1123))
+	message := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n\r\n%s\r\n",
+		e.From, strings.Join(e.To, ", "), subject, body)
+
+	if e.TestMode {
+		fmt.Printf("TEST MODE - Would email:\n%s\n", message)
+		return nil
+	}
+
+	addr := fmt.Sprintf("%s:%d", e.Host, e.Port)
+	auth := smtp.PlainAuth("", e.Username, e.Password, e.Host)
+	if err := smtp.SendMail(addr, auth, e.From, e.To, []byte(message)); err != nil {
+		return fmt.Errorf("failed to send email: %v", err)
+	}
+
+	return nil
+}
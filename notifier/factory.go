@@ -0,0 +1,100 @@
+package notifier
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// New builds a Notifier from the backend names selected for an app (e.g.
+// ["slack", "email"]), wrapping each backend in Retry and fanning
+// out through Multi when more than one is selected. Defaults to "googlechat"
+// when names is empty, preserving the tool's original behavior.
+func New(names []string, cfg Config) (Notifier, error) {
+	if len(names) == 0 {
+		names = []string{"googlechat"}
+	}
+
+	maxAttempts := cfg.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	baseDelay := cfg.BaseDelay
+	if baseDelay <= 0 {
+		baseDelay = 2 * time.Second
+	}
+
+	var backends Multi
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		n, err := build(name, cfg)
+		if err != nil {
+			return nil, err
+		}
+		backends = append(backends, Retry{Notifier: n, Name: name, MaxAttempts: maxAttempts, BaseDelay: baseDelay})
+	}
+
+	if len(backends) == 0 {
+		return nil, fmt.Errorf("no notifiers configured")
+	}
+	if len(backends) == 1 {
+		return backends[0], nil
+	}
+	return backends, nil
+}
+
+// NewNamed builds a retry-wrapped Notifier for every name in names, keyed by
+// name, so rule-based routing (see the rules package and Select) can target
+// individual channels instead of always fanning out to all of them.
+func NewNamed(names []string, cfg Config) (map[string]Notifier, error) {
+	maxAttempts := cfg.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	baseDelay := cfg.BaseDelay
+	if baseDelay <= 0 {
+		baseDelay = 2 * time.Second
+	}
+
+	out := make(map[string]Notifier, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		n, err := build(name, cfg)
+		if err != nil {
+			return nil, err
+		}
+		out[name] = Retry{Notifier: n, Name: name, MaxAttempts: maxAttempts, BaseDelay: baseDelay}
+	}
+	return out, nil
+}
+
+func build(name string, cfg Config) (Notifier, error) {
+	switch name {
+	case "googlechat":
+		return &GoogleChat{WebhookURL: cfg.GoogleChatWebhookURL, ReplyCallbackURL: cfg.ReplyCallbackURL, ReplySigningSecret: cfg.ReplySigningSecret, TestMode: cfg.TestMode}, nil
+	case "slack":
+		return &Slack{WebhookURL: cfg.SlackWebhookURL, ReplyCallbackURL: cfg.ReplyCallbackURL, ReplySigningSecret: cfg.ReplySigningSecret, TestMode: cfg.TestMode}, nil
+	case "discord":
+		return &Discord{WebhookURL: cfg.DiscordWebhookURL, TestMode: cfg.TestMode}, nil
+	case "teams":
+		return &Teams{WebhookURL: cfg.TeamsWebhookURL, TestMode: cfg.TestMode}, nil
+	case "email":
+		return &Email{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.EmailFrom,
+			To:       cfg.EmailTo,
+			TestMode: cfg.TestMode,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", name)
+	}
+}
@@ -0,0 +1,75 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wilik16/google-play-android-review-fetcher/reply"
+	"github.com/wilik16/google-play-android-review-fetcher/review"
+)
+
+// GoogleChat notifies a Google Chat space webhook with a plain text message,
+// plus a card with a Reply button when ReplyCallbackURL is set. This is the
+// tool's original (and default) notification backend.
+type GoogleChat struct {
+	WebhookURL       string
+	ReplyCallbackURL string
+	// ReplySigningSecret, when set, is used to sign the Reply button's
+	// callback URL so the replyserver can reject requests that didn't
+	// originate from this button.
+	ReplySigningSecret string
+	TestMode           bool
+}
+
+// Notify sends r to the configured Google Chat webhook.
+func (g *GoogleChat) Notify(ctx context.Context, r review.Review) error {
+	stars := strings.Repeat("⭐", r.Rating)
+	text := fmt.Sprintf("*New Review*\nRating: %s (%d)\nReview: %s\nAuthor: %s\nDevice: %s\nDate: %s",
+		stars, r.Rating, r.Text, r.Author, r.Device, r.Date.Format(time.RFC1123))
+
+	var message map[string]interface{}
+	if g.ReplyCallbackURL == "" {
+		message = map[string]interface{}{"text": text}
+	} else {
+		replyURL := fmt.Sprintf("%s?reviewId=%s&appId=%s", g.ReplyCallbackURL, url.QueryEscape(r.ReviewID), url.QueryEscape(r.AppID))
+		if g.ReplySigningSecret != "" {
+			replyURL += "&sig=" + url.QueryEscape(reply.Sign(g.ReplySigningSecret, r.ReviewID, r.AppID))
+		}
+		message = map[string]interface{}{
+			"text": text,
+			"cardsV2": []map[string]interface{}{
+				{
+					"cardId": "review-" + r.ReviewID,
+					"card": map[string]interface{}{
+						"sections": []map[string]interface{}{
+							{
+								"widgets": []map[string]interface{}{
+									{
+										"buttonList": map[string]interface{}{
+											"buttons": []map[string]interface{}{
+												{
+													"text":    "Reply",
+													"onClick": map[string]interface{}{"openLink": map[string]string{"url": replyURL}},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	if g.TestMode {
+		fmt.Printf("TEST MODE - Would send to Google Chat:\n%s\n", text)
+		return nil
+	}
+
+	return postJSON(ctx, g.WebhookURL, message)
+}
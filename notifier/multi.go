@@ -0,0 +1,28 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/wilik16/google-play-android-review-fetcher/review"
+)
+
+// Multi fans out a notification to every Notifier and aggregates errors so
+// one broken sink doesn't block the others.
+type Multi []Notifier
+
+// Notify sends r through every notifier in m, returning a combined error if
+// any of them fail.
+func (m Multi) Notify(ctx context.Context, r review.Review) error {
+	var errs []error
+	for _, n := range m {
+		if err := n.Notify(ctx, r); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d notifiers failed: %w", len(errs), len(m), errors.Join(errs...))
+	}
+	return nil
+}
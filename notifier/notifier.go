@@ -0,0 +1,14 @@
+// Package notifier delivers review notifications to one or more chat,
+// messaging, or email backends.
+package notifier
+
+import (
+	"context"
+
+	"github.com/wilik16/google-play-android-review-fetcher/review"
+)
+
+// Notifier delivers a single review notification.
+type Notifier interface {
+	Notify(ctx context.Context, r review.Review) error
+}
@@ -0,0 +1,46 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wilik16/google-play-android-review-fetcher/metrics"
+	"github.com/wilik16/google-play-android-review-fetcher/review"
+)
+
+// Retry wraps a Notifier and retries failed sends with exponential backoff,
+// replacing the old fixed 2-second sleep after every send. Name labels the
+// webhook latency/failure metrics recorded for every attempt.
+type Retry struct {
+	Notifier    Notifier
+	Name        string
+	MaxAttempts int
+	BaseDelay   time.Duration
+}
+
+// Notify attempts delivery up to MaxAttempts times, doubling BaseDelay
+// between attempts.
+func (r Retry) Notify(ctx context.Context, rv review.Review) error {
+	delay := r.BaseDelay
+	var lastErr error
+	for attempt := 1; attempt <= r.MaxAttempts; attempt++ {
+		start := time.Now()
+		lastErr = r.Notifier.Notify(ctx, rv)
+		metrics.WebhookLatency.WithLabelValues(r.Name).Observe(time.Since(start).Seconds())
+		if lastErr == nil {
+			return nil
+		}
+		metrics.WebhookFailures.WithLabelValues(r.Name).Inc()
+		if attempt == r.MaxAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+	return fmt.Errorf("notify failed after %d attempts: %v", r.MaxAttempts, lastErr)
+}
@@ -0,0 +1,21 @@
+package notifier
+
+// Select builds a Notifier that fans out to only the named backends in all,
+// letting the rules package route a review to specific channels instead of
+// an app's full notifier set. Unknown names are ignored. Returns nil if none
+// of names are present in all.
+func Select(all map[string]Notifier, names []string) Notifier {
+	var m Multi
+	for _, name := range names {
+		if n, ok := all[name]; ok {
+			m = append(m, n)
+		}
+	}
+	if len(m) == 0 {
+		return nil
+	}
+	if len(m) == 1 {
+		return m[0]
+	}
+	return m
+}
@@ -0,0 +1,70 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/wilik16/google-play-android-review-fetcher/reply"
+	"github.com/wilik16/google-play-android-review-fetcher/review"
+)
+
+// Slack notifies a Slack incoming webhook using Block Kit.
+type Slack struct {
+	WebhookURL       string
+	ReplyCallbackURL string
+	// ReplySigningSecret, when set, is used to sign the Reply button's
+	// callback URL so the replyserver can reject requests that didn't
+	// originate from this button.
+	ReplySigningSecret string
+	TestMode           bool
+}
+
+// Notify sends r to the configured Slack webhook as a Block Kit message.
+func (s *Slack) Notify(ctx context.Context, r review.Review) error {
+	stars := strings.Repeat("⭐", r.Rating)
+	blocks := []map[string]interface{}{
+		{
+			"type": "section",
+			"text": map[string]string{
+				"type": "mrkdwn",
+				"text": fmt.Sprintf("*New Review* %s (%d)\n%s", stars, r.Rating, r.Text),
+			},
+		},
+		{
+			"type": "context",
+			"elements": []map[string]string{
+				{"type": "mrkdwn", "text": fmt.Sprintf("*Author:* %s", r.Author)},
+				{"type": "mrkdwn", "text": fmt.Sprintf("*Device:* %s", r.Device)},
+				{"type": "mrkdwn", "text": fmt.Sprintf("*Date:* %s", r.Date.Format(time.RFC1123))},
+			},
+		},
+	}
+	if s.ReplyCallbackURL != "" {
+		replyURL := fmt.Sprintf("%s?reviewId=%s&appId=%s", s.ReplyCallbackURL, url.QueryEscape(r.ReviewID), url.QueryEscape(r.AppID))
+		if s.ReplySigningSecret != "" {
+			replyURL += "&sig=" + url.QueryEscape(reply.Sign(s.ReplySigningSecret, r.ReviewID, r.AppID))
+		}
+		blocks = append(blocks, map[string]interface{}{
+			"type": "actions",
+			"elements": []map[string]interface{}{
+				{
+					"type":  "button",
+					"text":  map[string]string{"type": "plain_text", "text": "Reply"},
+					"url":   replyURL,
+					"value": r.ReviewID,
+				},
+			},
+		})
+	}
+	payload := map[string]interface{}{"blocks": blocks}
+
+	if s.TestMode {
+		fmt.Printf("TEST MODE - Would send to Slack:\n%+v\n", payload)
+		return nil
+	}
+
+	return postJSON(ctx, s.WebhookURL, payload)
+}
@@ -0,0 +1,49 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/wilik16/google-play-android-review-fetcher/review"
+)
+
+// Teams notifies a Microsoft Teams incoming webhook using an adaptive card.
+type Teams struct {
+	WebhookURL string
+	TestMode   bool
+}
+
+// Notify sends r to the configured Teams webhook as an adaptive card.
+func (t *Teams) Notify(ctx context.Context, r review.Review) error {
+	stars := strings.Repeat("⭐", r.Rating)
+	card := map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{
+			{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body": []map[string]interface{}{
+						{"type": "TextBlock", "text": fmt.Sprintf("New Review %s (%d)", stars, r.Rating), "weight": "bolder", "size": "medium"},
+						{"type": "TextBlock", "text": r.Text, "wrap": true},
+						{"type": "FactSet", "facts": []map[string]string{
+							{"title": "Author", "value": r.Author},
+							{"title": "Device", "value": r.Device},
+							{"title": "Date", "value": r.Date.Format(time.RFC1123)},
+						}},
+					},
+				},
+			},
+		},
+	}
+
+	if t.TestMode {
+		fmt.Printf("TEST MODE - Would send to Teams:\n%+v\n", card)
+		return nil
+	}
+
+	return postJSON(ctx, t.WebhookURL, card)
+}
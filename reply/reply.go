@@ -0,0 +1,75 @@
+// Package reply posts developer replies back to the Play Store and keeps
+// the local store in sync with the result.
+package reply
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/wilik16/google-play-android-review-fetcher/review"
+	"github.com/wilik16/google-play-android-review-fetcher/store"
+)
+
+// Config holds the dependencies needed to reply to a review and persist the
+// result.
+type Config struct {
+	Service     *androidpublisher.Service
+	PackageName string
+	Store       store.ReviewStore
+}
+
+// Send posts replyText as a developer reply to reviewID, re-fetches the
+// review so DeveloperComment is captured alongside the user's comment, and
+// persists the result to cfg.Store.
+func Send(ctx context.Context, cfg Config, reviewID, replyText string) (review.Review, error) {
+	_, err := cfg.Service.Reviews.Reply(cfg.PackageName, reviewID, &androidpublisher.ReviewsReplyRequest{
+		ReplyText: replyText,
+	}).Context(ctx).Do()
+	if err != nil {
+		return review.Review{}, fmt.Errorf("failed to reply to review %s: %v", reviewID, err)
+	}
+
+	fresh, err := cfg.Service.Reviews.Get(cfg.PackageName, reviewID).Context(ctx).Do()
+	if err != nil {
+		return review.Review{}, fmt.Errorf("failed to re-fetch review %s: %v", reviewID, err)
+	}
+
+	r, exists, err := cfg.Store.Get(ctx, reviewID)
+	if err != nil {
+		return review.Review{}, fmt.Errorf("failed to look up review %s: %v", reviewID, err)
+	}
+	if !exists {
+		r = review.Review{ReviewID: reviewID}
+	}
+	r.Author = fresh.AuthorName
+
+	for _, comment := range fresh.Comments {
+		if comment.UserComment != nil {
+			uc := comment.UserComment
+			r.Rating = int(uc.StarRating)
+			r.Text = strings.TrimSpace(uc.Text)
+			r.Device = "Unknown"
+			if uc.DeviceMetadata != nil {
+				r.Device = uc.DeviceMetadata.ProductName
+			}
+			r.Date = time.Unix(uc.LastModified.Seconds, 0)
+		}
+		if comment.DeveloperComment != nil {
+			r.DeveloperComment = comment.DeveloperComment.Text
+		}
+	}
+	if r.DeveloperComment == "" {
+		r.DeveloperComment = replyText
+	}
+	r.RepliedAt = time.Now()
+
+	if err := cfg.Store.Upsert(ctx, r); err != nil {
+		return review.Review{}, fmt.Errorf("failed to persist reply for review %s: %v", reviewID, err)
+	}
+
+	return r, nil
+}
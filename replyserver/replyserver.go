@@ -0,0 +1,135 @@
+// Package replyserver runs the small HTTP callback server that a Slack
+// action button or Google Chat card button hits to post a developer reply,
+// turning the tool from read-only into a triage workflow.
+package replyserver
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+
+	"github.com/wilik16/google-play-android-review-fetcher/reply"
+)
+
+// Server serves the /reply form that notifier buttons link to. Configs is
+// keyed by app ID so a single callback server can serve every configured
+// app; each button URL carries its app's ID alongside the review ID.
+type Server struct {
+	Addr    string
+	Configs map[string]reply.Config
+	// Secret, when set, is required to match the reply.Sign signature of
+	// reviewId/appId on every request, passed by the notifier as the "sig"
+	// query/form parameter. This must match the secret the notifier
+	// backends were built with (notifier.Config.ReplySigningSecret). If
+	// empty, requests are not authenticated.
+	Secret string
+}
+
+// ListenAndServe starts the callback server and blocks until ctx is
+// cancelled or the server fails to start.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/reply", s.handleReply)
+
+	srv := &http.Server{Addr: s.Addr, Handler: mux}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.ListenAndServe() }()
+
+	select {
+	case <-ctx.Done():
+		return srv.Shutdown(context.Background())
+	case err := <-errCh:
+		if err != nil && err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	}
+}
+
+// handleReply renders a small reply form on GET (as linked to from a Slack
+// action button or Google Chat card button) and posts the reply on submit.
+func (s *Server) handleReply(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		s.renderForm(w, q.Get("reviewId"), q.Get("appId"), q.Get("sig"))
+	case http.MethodPost:
+		s.submitReply(w, r)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// authenticate reports whether sig is a valid reply.Sign signature for
+// reviewID/appID under s.Secret. It always succeeds when s.Secret is empty,
+// preserving the old unauthenticated behavior for deployments that haven't
+// set REPLY_SIGNING_SECRET yet.
+func (s *Server) authenticate(reviewID, appID, sig string) bool {
+	if s.Secret == "" {
+		return true
+	}
+	return reply.Verify(s.Secret, reviewID, appID, sig)
+}
+
+func (s *Server) renderForm(w http.ResponseWriter, reviewID, appID, sig string) {
+	if reviewID == "" || appID == "" {
+		http.Error(w, "reviewId and appId are required", http.StatusBadRequest)
+		return
+	}
+	if _, ok := s.Configs[appID]; !ok {
+		http.Error(w, fmt.Sprintf("unknown appId %q", appID), http.StatusNotFound)
+		return
+	}
+	if !s.authenticate(reviewID, appID, sig) {
+		http.Error(w, "invalid or missing signature", http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, `<!doctype html>
+<form method="post">
+  <input type="hidden" name="reviewId" value="%s">
+  <input type="hidden" name="appId" value="%s">
+  <input type="hidden" name="sig" value="%s">
+  <textarea name="text" rows="4" cols="60" placeholder="Reply to this review..."></textarea><br>
+  <button type="submit">Send reply</button>
+</form>`, html.EscapeString(reviewID), html.EscapeString(appID), html.EscapeString(sig))
+}
+
+func (s *Server) submitReply(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, fmt.Sprintf("invalid form: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	reviewID := r.FormValue("reviewId")
+	appID := r.FormValue("appId")
+	text := r.FormValue("text")
+	sig := r.FormValue("sig")
+	if reviewID == "" || appID == "" || text == "" {
+		http.Error(w, "reviewId, appId and text are required", http.StatusBadRequest)
+		return
+	}
+
+	cfg, ok := s.Configs[appID]
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown appId %q", appID), http.StatusNotFound)
+		return
+	}
+	if !s.authenticate(reviewID, appID, sig) {
+		http.Error(w, "invalid or missing signature", http.StatusForbidden)
+		return
+	}
+
+	if _, err := reply.Send(r.Context(), cfg, reviewID, text); err != nil {
+		slog.Error("failed to reply to review", "reviewId", reviewID, "appId", appID, "error", err)
+		http.Error(w, "failed to send reply", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, "Reply sent for review %s.", html.EscapeString(reviewID))
+}
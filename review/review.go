@@ -0,0 +1,35 @@
+// Package review defines the shared Review type passed between the runner,
+// notifier, and store packages.
+package review
+
+import "time"
+
+// Review represents a single review from the Play Store.
+type Review struct {
+	// AppID identifies which configured app this review belongs to, for
+	// deployments that track more than one Play Store app.
+	AppID    string    `json:"appId,omitempty"`
+	ReviewID string    `json:"reviewId"`
+	Rating   int       `json:"rating"`
+	Text     string    `json:"text"`
+	Author   string    `json:"author"`
+	Device   string    `json:"device"`
+	Date     time.Time `json:"date"`
+	Notified bool      `json:"notified"`
+
+	// DeveloperComment and RepliedAt are populated once a reply has been
+	// posted back to the Play Store for this review.
+	DeveloperComment string    `json:"developerComment,omitempty"`
+	RepliedAt        time.Time `json:"repliedAt,omitempty"`
+
+	// Sentiment and Labels are populated by the classifier package and
+	// drive the rules package's notifier routing.
+	Sentiment string   `json:"sentiment,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+}
+
+// RatingRange represents a range of ratings to filter.
+type RatingRange struct {
+	Start int `yaml:"start" json:"start"`
+	End   int `yaml:"end" json:"end"`
+}
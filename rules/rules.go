@@ -0,0 +1,109 @@
+// Package rules implements the rule-based notifier routing engine: each
+// rule maps a {rating range, labels, device regex} condition to the
+// notifier channels that should receive matching reviews, generalizing the
+// tool's original single rating-range filter into a real routing layer.
+package rules
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/wilik16/google-play-android-review-fetcher/review"
+)
+
+// Rule routes reviews matching every non-empty condition field to
+// Notifiers. An empty condition matches everything.
+type Rule struct {
+	RatingRange *review.RatingRange `yaml:"ratingRange,omitempty"`
+	Labels      []string            `yaml:"labels,omitempty"`
+	DeviceRegex string              `yaml:"deviceRegex,omitempty"`
+	Notifiers   []string            `yaml:"notifiers"`
+
+	deviceRe *regexp.Regexp
+}
+
+func (r *Rule) matches(rv review.Review) bool {
+	if r.RatingRange != nil && (rv.Rating < r.RatingRange.Start || rv.Rating > r.RatingRange.End) {
+		return false
+	}
+	for _, label := range r.Labels {
+		if !hasLabel(rv.Labels, label) {
+			return false
+		}
+	}
+	if r.deviceRe != nil && !r.deviceRe.MatchString(rv.Device) {
+		return false
+	}
+	return true
+}
+
+func hasLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// Engine holds the compiled rule set loaded from a rules.yaml file.
+type Engine struct {
+	Rules []Rule
+}
+
+// config is the on-disk shape of a rules.yaml file.
+type config struct {
+	Rules []Rule `yaml:"rules"`
+}
+
+// Load reads and compiles a rules.yaml file.
+func Load(path string) (*Engine, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rules config: %v", err)
+	}
+
+	var cfg config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse rules config: %v", err)
+	}
+
+	for i := range cfg.Rules {
+		rule := &cfg.Rules[i]
+		if rule.DeviceRegex != "" {
+			re, err := regexp.Compile(rule.DeviceRegex)
+			if err != nil {
+				return nil, fmt.Errorf("rules[%d]: invalid deviceRegex: %v", i, err)
+			}
+			rule.deviceRe = re
+		}
+		if len(rule.Notifiers) == 0 {
+			return nil, fmt.Errorf("rules[%d]: notifiers is required", i)
+		}
+	}
+
+	return &Engine{Rules: cfg.Rules}, nil
+}
+
+// Route returns the union of notifier names from every rule matching rv, in
+// rule order with duplicates removed. An empty result means no rule matched
+// and the caller should fall back to its default notifier.
+func (e *Engine) Route(rv review.Review) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, rule := range e.Rules {
+		if !rule.matches(rv) {
+			continue
+		}
+		for _, n := range rule.Notifiers {
+			if !seen[n] {
+				seen[n] = true
+				names = append(names, n)
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,214 @@
+// Package runner implements the long-running poll loop that fetches reviews
+// from the Play Store, notifies on new ones, and persists them to a store.
+package runner
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/androidpublisher/v3"
+
+	"github.com/wilik16/google-play-android-review-fetcher/classifier"
+	"github.com/wilik16/google-play-android-review-fetcher/metrics"
+	"github.com/wilik16/google-play-android-review-fetcher/notifier"
+	"github.com/wilik16/google-play-android-review-fetcher/review"
+	"github.com/wilik16/google-play-android-review-fetcher/rules"
+	"github.com/wilik16/google-play-android-review-fetcher/store"
+)
+
+const (
+	// maxBackoff caps the exponential backoff applied after transient API errors.
+	maxBackoff = 2 * time.Minute
+	// initialBackoff is the first delay used after a transient API error.
+	initialBackoff = 2 * time.Second
+)
+
+// Config holds everything the poll loop needs for one app.
+type Config struct {
+	AppID        string
+	Service      *androidpublisher.Service
+	PackageName  string
+	Notifier     notifier.Notifier
+	Store        store.ReviewStore
+	RatingRange  *review.RatingRange
+	PollInterval time.Duration
+
+	// Classifier, if set, attaches sentiment and keyword labels to every
+	// new review before it's routed and persisted.
+	Classifier *classifier.Classifier
+	// Rules, if set, routes a review to the subset of NamedNotifiers whose
+	// rule conditions match, falling back to Notifier when nothing matches.
+	Rules          *rules.Engine
+	NamedNotifiers map[string]notifier.Notifier
+	// NotifierNames labels the reviews_notified_total metric; it should list
+	// the backend names Notifier was built from (see notifier.New).
+	NotifierNames []string
+}
+
+// Run polls service.Reviews.List on cfg.PollInterval until ctx is cancelled,
+// paginating through all results each pass. Every review is notified and
+// persisted to cfg.Store as soon as it's found, so a SIGINT/SIGTERM shutdown
+// never loses in-memory state.
+func Run(ctx context.Context, cfg Config) error {
+	logger := slog.Default()
+	if cfg.AppID != "" {
+		logger = logger.With("appId", cfg.AppID)
+	}
+
+	backoff := initialBackoff
+	for {
+		newReviews, err := poll(ctx, cfg, logger)
+		if err != nil {
+			logger.Warn("poll failed, backing off", "backoff", backoff, "error", err)
+			metrics.RateLimitSleepSeconds.WithLabelValues(cfg.AppID).Add(backoff.Seconds())
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+			if backoff > maxBackoff {
+				backoff = maxBackoff
+			}
+			continue
+		}
+		backoff = initialBackoff
+
+		if len(newReviews) > 0 {
+			for _, r := range newReviews {
+				logger.Info("new review", "reviewId", r.ReviewID, "rating", r.Rating, "device", r.Device)
+			}
+		} else {
+			logger.Info("no new reviews found")
+		}
+
+		if cfg.PollInterval <= 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("shutdown requested, exiting poll loop")
+			return nil
+		case <-time.After(cfg.PollInterval):
+		}
+	}
+}
+
+// poll fetches every page of reviews for the configured package, notifying on
+// and persisting any review that hasn't been successfully notified yet.
+func poll(ctx context.Context, cfg Config, logger *slog.Logger) ([]review.Review, error) {
+	var newReviews []review.Review
+
+	start := time.Now()
+	err := fetchPages(ctx, cfg, func(page *androidpublisher.ReviewsListResponse) error {
+		metrics.ReviewsFetched.WithLabelValues(cfg.AppID).Add(float64(len(page.Reviews)))
+		logger.Info("fetched page from API", "count", len(page.Reviews))
+
+		for _, r := range page.Reviews {
+			rating := int(r.Comments[0].UserComment.StarRating)
+			if cfg.RatingRange != nil && (rating < cfg.RatingRange.Start || rating > cfg.RatingRange.End) {
+				continue
+			}
+
+			device := "Unknown"
+			if r.Comments[0].UserComment.DeviceMetadata != nil {
+				device = r.Comments[0].UserComment.DeviceMetadata.ProductName
+			}
+
+			existing, exists, err := cfg.Store.Get(ctx, r.ReviewId)
+			if err != nil {
+				return fmt.Errorf("failed to look up review %s: %v", r.ReviewId, err)
+			}
+			if exists && existing.Notified {
+				continue
+			}
+
+			newReview := review.Review{
+				AppID:    cfg.AppID,
+				ReviewID: r.ReviewId,
+				Rating:   rating,
+				Text:     cleanText(r.Comments[0].UserComment.Text),
+				Author:   r.AuthorName,
+				Device:   device,
+				Date:     time.Unix(r.Comments[0].UserComment.LastModified.Seconds, 0),
+				Notified: false,
+			}
+
+			if cfg.Classifier != nil {
+				result := cfg.Classifier.Classify(newReview.Text)
+				newReview.Sentiment = result.Sentiment
+				newReview.Labels = result.Labels
+			}
+
+			notify := cfg.Notifier
+			notifierNames := cfg.NotifierNames
+			if cfg.Rules != nil {
+				if names := cfg.Rules.Route(newReview); len(names) > 0 {
+					if selected := notifier.Select(cfg.NamedNotifiers, names); selected != nil {
+						notify = selected
+						notifierNames = names
+					}
+				}
+			}
+
+			if err := notify.Notify(ctx, newReview); err != nil {
+				logger.Warn("failed to notify about review", "reviewId", r.ReviewId, "rating", rating, "device", device, "error", err)
+				if err := cfg.Store.Upsert(ctx, newReview); err != nil {
+					logger.Error("failed to persist review", "reviewId", r.ReviewId, "error", err)
+				}
+				continue
+			}
+
+			newReview.Notified = true
+			if err := cfg.Store.Upsert(ctx, newReview); err != nil {
+				return fmt.Errorf("failed to persist review %s: %v", r.ReviewId, err)
+			}
+			for _, name := range notifierNames {
+				metrics.ReviewsNotified.WithLabelValues(cfg.AppID, strconv.Itoa(rating), name).Inc()
+			}
+			newReviews = append(newReviews, newReview)
+		}
+		return nil
+	})
+	metrics.APILatency.WithLabelValues(cfg.AppID, "reviews.list").Observe(time.Since(start).Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reviews: %v", err)
+	}
+
+	return newReviews, nil
+}
+
+// cleanText removes leading and trailing whitespace and tabs from text.
+func cleanText(text string) string {
+	return strings.TrimSpace(text)
+}
+
+// fetchPages walks every page of cfg.Service.Reviews.List for cfg.PackageName,
+// invoking fn once per page. Reviews.List uses the legacy token-based
+// pagination style and has no generated Pages() helper, so pagination is
+// driven by hand via ReviewsListResponse.TokenPagination.NextPageToken.
+func fetchPages(ctx context.Context, cfg Config, fn func(*androidpublisher.ReviewsListResponse) error) error {
+	token := ""
+	for {
+		call := cfg.Service.Reviews.List(cfg.PackageName).Context(ctx)
+		if token != "" {
+			call = call.Token(token)
+		}
+		page, err := call.Do()
+		if err != nil {
+			return err
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+		if page.TokenPagination == nil || page.TokenPagination.NextPageToken == "" {
+			return nil
+		}
+		token = page.TokenPagination.NextPageToken
+	}
+}
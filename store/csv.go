@@ -0,0 +1,185 @@
+package store
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wilik16/google-play-android-review-fetcher/review"
+)
+
+// CSVStore persists reviews in a single CSV file, rewriting the whole file on
+// every write. This is the tool's original storage format; it is simple but
+// not safe for concurrent writers across processes and keeps every review
+// in memory.
+type CSVStore struct {
+	path string
+
+	mu   sync.Mutex
+	data map[string]review.Review
+}
+
+// NewCSVStore loads path into memory, creating an empty store if it doesn't
+// exist yet.
+func NewCSVStore(path string) (*CSVStore, error) {
+	s := &CSVStore{path: path, data: make(map[string]review.Review)}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *CSVStore) load() error {
+	if _, err := os.Stat(s.path); os.IsNotExist(err) {
+		return nil
+	}
+
+	file, err := os.Open(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to open CSV file: %v", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return fmt.Errorf("failed to read CSV: %v", err)
+	}
+
+	// Skip header
+	for _, record := range records[1:] {
+		date, _ := time.Parse(time.RFC3339, record[5])
+		notified, _ := strconv.ParseBool(record[6])
+		rating, _ := strconv.Atoi(record[1])
+
+		r := review.Review{
+			ReviewID: record[0],
+			Rating:   rating,
+			Text:     record[2],
+			Author:   record[3],
+			Device:   record[4],
+			Date:     date,
+			Notified: notified,
+		}
+		if len(record) > 7 {
+			r.DeveloperComment = record[7]
+		}
+		if len(record) > 8 {
+			r.RepliedAt, _ = time.Parse(time.RFC3339, record[8])
+		}
+		if len(record) > 9 {
+			r.AppID = record[9]
+		}
+		if len(record) > 10 {
+			r.Sentiment = record[10]
+		}
+		if len(record) > 11 && record[11] != "" {
+			r.Labels = strings.Split(record[11], ",")
+		}
+		s.data[record[0]] = r
+	}
+
+	return nil
+}
+
+// flush must be called with s.mu held.
+func (s *CSVStore) flush() error {
+	file, err := os.Create(s.path)
+	if err != nil {
+		return fmt.Errorf("failed to create CSV file: %v", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	defer writer.Flush()
+
+	header := []string{"Review ID", "Rating", "Review Text", "Author", "Device", "Date", "Notified", "Developer Comment", "Replied At", "App ID", "Sentiment", "Labels"}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write header: %v", err)
+	}
+
+	for _, r := range s.data {
+		var repliedAt string
+		if !r.RepliedAt.IsZero() {
+			repliedAt = r.RepliedAt.Format(time.RFC3339)
+		}
+		record := []string{
+			r.ReviewID,
+			strconv.Itoa(r.Rating),
+			r.Text,
+			r.Author,
+			r.Device,
+			r.Date.Format(time.RFC3339),
+			strconv.FormatBool(r.Notified),
+			r.DeveloperComment,
+			repliedAt,
+			r.AppID,
+			r.Sentiment,
+			strings.Join(r.Labels, ","),
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write record: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// Get returns the review with the given ID, and whether it exists.
+func (s *CSVStore) Get(ctx context.Context, reviewID string) (review.Review, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.data[reviewID]
+	return r, ok, nil
+}
+
+// Upsert inserts or updates r and rewrites the CSV file.
+func (s *CSVStore) Upsert(ctx context.Context, r review.Review) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[r.ReviewID] = r
+	return s.flush()
+}
+
+// List returns every stored review.
+func (s *CSVStore) List(ctx context.Context) ([]review.Review, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]review.Review, 0, len(s.data))
+	for _, r := range s.data {
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// ListFiltered returns every stored review matching f via a linear scan;
+// CSVStore has no index to push the filter down to.
+func (s *CSVStore) ListFiltered(ctx context.Context, f Filter) ([]review.Review, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []review.Review
+	for _, r := range s.data {
+		if f.match(r) {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+// MarkNotified marks reviewID as notified and rewrites the CSV file.
+func (s *CSVStore) MarkNotified(ctx context.Context, reviewID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.data[reviewID]
+	if !ok {
+		return fmt.Errorf("review %s not found", reviewID)
+	}
+	r.Notified = true
+	s.data[reviewID] = r
+	return s.flush()
+}
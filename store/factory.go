@@ -0,0 +1,20 @@
+package store
+
+import "fmt"
+
+// New builds a ReviewStore from STORE_DRIVER ("csv", "sqlite", or
+// "postgres"). csvPath is used directly by the csv driver; dsn is passed
+// through to the sqlite/postgres drivers. Defaults to csv when driver is
+// empty, preserving the tool's original behavior.
+func New(driver, csvPath, dsn string) (ReviewStore, error) {
+	switch driver {
+	case "", "csv":
+		return NewCSVStore(csvPath)
+	case "sqlite":
+		return NewSQLiteStore(dsn)
+	case "postgres":
+		return NewPostgresStore(dsn)
+	default:
+		return nil, fmt.Errorf("unknown store driver %q", driver)
+	}
+}
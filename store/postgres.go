@@ -0,0 +1,199 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	_ "github.com/lib/pq"
+
+	"github.com/wilik16/google-play-android-review-fetcher/review"
+)
+
+// scanPostgresReview scans a row with the standard reviews column order into
+// a review.Review, handling the nullable replied_at column.
+func scanPostgresReview(row rowScanner) (review.Review, error) {
+	var r review.Review
+	var repliedAt sql.NullTime
+	var labelsStr string
+	err := row.Scan(&r.ReviewID, &r.Rating, &r.Text, &r.Author, &r.Device, &r.Date, &r.Notified, &r.DeveloperComment, &repliedAt, &r.AppID, &r.Sentiment, &labelsStr)
+	if err != nil {
+		return review.Review{}, err
+	}
+	if repliedAt.Valid {
+		r.RepliedAt = repliedAt.Time
+	}
+	if labelsStr != "" {
+		r.Labels = strings.Split(labelsStr, ",")
+	}
+	return r, nil
+}
+
+// PostgresStore persists reviews in a Postgres database, enabling indexed
+// queries by rating/date/notified status that CSVStore cannot offer.
+type PostgresStore struct {
+	db *sql.DB
+}
+
+// NewPostgresStore opens dsn (a "postgres://" connection string) and ensures
+// the reviews table exists.
+func NewPostgresStore(dsn string) (*PostgresStore, error) {
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres store: %v", err)
+	}
+
+	s := &PostgresStore{db: db}
+	if err := s.migrate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *PostgresStore) migrate() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS reviews (
+			review_id         TEXT PRIMARY KEY,
+			rating            INTEGER NOT NULL,
+			text              TEXT NOT NULL,
+			author            TEXT NOT NULL,
+			device            TEXT NOT NULL,
+			date              TIMESTAMPTZ NOT NULL,
+			notified          BOOLEAN NOT NULL,
+			developer_comment TEXT NOT NULL DEFAULT '',
+			replied_at        TIMESTAMPTZ,
+			app_id            TEXT NOT NULL DEFAULT '',
+			sentiment         TEXT NOT NULL DEFAULT '',
+			labels            TEXT NOT NULL DEFAULT ''
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to migrate postgres store: %v", err)
+	}
+
+	for _, stmt := range []string{
+		`CREATE INDEX IF NOT EXISTS idx_reviews_rating ON reviews(rating)`,
+		`CREATE INDEX IF NOT EXISTS idx_reviews_date ON reviews(date)`,
+		`CREATE INDEX IF NOT EXISTS idx_reviews_notified ON reviews(notified)`,
+	} {
+		if _, err := s.db.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to migrate postgres store: %v", err)
+		}
+	}
+	return nil
+}
+
+// Get returns the review with the given ID, and whether it exists.
+func (s *PostgresStore) Get(ctx context.Context, reviewID string) (review.Review, bool, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT review_id, rating, text, author, device, date, notified, developer_comment, replied_at, app_id, sentiment, labels FROM reviews WHERE review_id = $1`, reviewID)
+
+	r, err := scanPostgresReview(row)
+	if err == sql.ErrNoRows {
+		return review.Review{}, false, nil
+	}
+	if err != nil {
+		return review.Review{}, false, fmt.Errorf("failed to get review: %v", err)
+	}
+	return r, true, nil
+}
+
+// Upsert inserts a new review or updates an existing one by ReviewID.
+func (s *PostgresStore) Upsert(ctx context.Context, r review.Review) error {
+	var repliedAt sql.NullTime
+	if !r.RepliedAt.IsZero() {
+		repliedAt = sql.NullTime{Time: r.RepliedAt, Valid: true}
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO reviews (review_id, rating, text, author, device, date, notified, developer_comment, replied_at, app_id, sentiment, labels)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (review_id) DO UPDATE SET
+			rating = excluded.rating,
+			text = excluded.text,
+			author = excluded.author,
+			device = excluded.device,
+			date = excluded.date,
+			notified = excluded.notified,
+			developer_comment = excluded.developer_comment,
+			replied_at = excluded.replied_at,
+			app_id = excluded.app_id,
+			sentiment = excluded.sentiment,
+			labels = excluded.labels
+	`, r.ReviewID, r.Rating, r.Text, r.Author, r.Device, r.Date, r.Notified, r.DeveloperComment, repliedAt, r.AppID, r.Sentiment, strings.Join(r.Labels, ","))
+	if err != nil {
+		return fmt.Errorf("failed to upsert review: %v", err)
+	}
+	return nil
+}
+
+// List returns every stored review.
+func (s *PostgresStore) List(ctx context.Context) ([]review.Review, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT review_id, rating, text, author, device, date, notified, developer_comment, replied_at, app_id, sentiment, labels FROM reviews`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %v", err)
+	}
+	defer rows.Close()
+
+	var out []review.Review
+	for rows.Next() {
+		r, err := scanPostgresReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan review: %v", err)
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// ListFiltered returns every stored review matching f, served from the
+// rating/date/notified indexes created in migrate().
+func (s *PostgresStore) ListFiltered(ctx context.Context, f Filter) ([]review.Review, error) {
+	query := `SELECT review_id, rating, text, author, device, date, notified, developer_comment, replied_at, app_id, sentiment, labels FROM reviews WHERE 1=1`
+	var args []interface{}
+	if f.Rating != 0 {
+		args = append(args, f.Rating)
+		query += fmt.Sprintf(" AND rating = $%d", len(args))
+	}
+	if !f.Since.IsZero() {
+		args = append(args, f.Since)
+		query += fmt.Sprintf(" AND date >= $%d", len(args))
+	}
+	if !f.Until.IsZero() {
+		args = append(args, f.Until)
+		query += fmt.Sprintf(" AND date <= $%d", len(args))
+	}
+	if f.Notified != nil {
+		args = append(args, *f.Notified)
+		query += fmt.Sprintf(" AND notified = $%d", len(args))
+	}
+	for _, label := range f.Labels {
+		args = append(args, "%,"+label+",%")
+		query += fmt.Sprintf(" AND (',' || labels || ',') LIKE $%d", len(args))
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %v", err)
+	}
+	defer rows.Close()
+
+	var out []review.Review
+	for rows.Next() {
+		r, err := scanPostgresReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan review: %v", err)
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// MarkNotified marks reviewID as notified.
+func (s *PostgresStore) MarkNotified(ctx context.Context, reviewID string) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE reviews SET notified = true WHERE review_id = $1`, reviewID)
+	if err != nil {
+		return fmt.Errorf("failed to mark review notified: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/wilik16/google-play-android-review-fetcher/review"
+)
+
+// SQLiteStore persists reviews in a SQLite database via modernc.org/sqlite,
+// avoiding CSVStore's whole-file rewrite on every write and its in-memory
+// copy of every review.
+type SQLiteStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteStore opens dsn (a file path or "file::memory:") and ensures the
+// reviews table exists.
+func NewSQLiteStore(dsn string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite store: %v", err)
+	}
+
+	s := &SQLiteStore{db: db}
+	if err := s.migrate(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *SQLiteStore) migrate() error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS reviews (
+			review_id         TEXT PRIMARY KEY,
+			rating            INTEGER NOT NULL,
+			text              TEXT NOT NULL,
+			author            TEXT NOT NULL,
+			device            TEXT NOT NULL,
+			date              TEXT NOT NULL,
+			notified          INTEGER NOT NULL,
+			developer_comment TEXT NOT NULL DEFAULT '',
+			replied_at        TEXT NOT NULL DEFAULT '',
+			app_id            TEXT NOT NULL DEFAULT '',
+			sentiment         TEXT NOT NULL DEFAULT '',
+			labels            TEXT NOT NULL DEFAULT ''
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to migrate sqlite store: %v", err)
+	}
+
+	for _, stmt := range []string{
+		`CREATE INDEX IF NOT EXISTS idx_reviews_rating ON reviews(rating)`,
+		`CREATE INDEX IF NOT EXISTS idx_reviews_date ON reviews(date)`,
+		`CREATE INDEX IF NOT EXISTS idx_reviews_notified ON reviews(notified)`,
+	} {
+		if _, err := s.db.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to migrate sqlite store: %v", err)
+		}
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReview(row rowScanner) (review.Review, error) {
+	var r review.Review
+	var dateStr, repliedAtStr, labelsStr string
+	if err := row.Scan(&r.ReviewID, &r.Rating, &r.Text, &r.Author, &r.Device, &dateStr, &r.Notified, &r.DeveloperComment, &repliedAtStr, &r.AppID, &r.Sentiment, &labelsStr); err != nil {
+		return review.Review{}, err
+	}
+	r.Date, _ = time.Parse(time.RFC3339, dateStr)
+	if repliedAtStr != "" {
+		r.RepliedAt, _ = time.Parse(time.RFC3339, repliedAtStr)
+	}
+	if labelsStr != "" {
+		r.Labels = strings.Split(labelsStr, ",")
+	}
+	return r, nil
+}
+
+// Get returns the review with the given ID, and whether it exists.
+func (s *SQLiteStore) Get(ctx context.Context, reviewID string) (review.Review, bool, error) {
+	row := s.db.QueryRowContext(ctx,
+		`SELECT review_id, rating, text, author, device, date, notified, developer_comment, replied_at, app_id, sentiment, labels FROM reviews WHERE review_id = ?`, reviewID)
+	r, err := scanReview(row)
+	if err == sql.ErrNoRows {
+		return review.Review{}, false, nil
+	}
+	if err != nil {
+		return review.Review{}, false, fmt.Errorf("failed to get review: %v", err)
+	}
+	return r, true, nil
+}
+
+// Upsert inserts a new review or updates an existing one by ReviewID.
+func (s *SQLiteStore) Upsert(ctx context.Context, r review.Review) error {
+	var repliedAt string
+	if !r.RepliedAt.IsZero() {
+		repliedAt = r.RepliedAt.Format(time.RFC3339)
+	}
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO reviews (review_id, rating, text, author, device, date, notified, developer_comment, replied_at, app_id, sentiment, labels)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(review_id) DO UPDATE SET
+			rating = excluded.rating,
+			text = excluded.text,
+			author = excluded.author,
+			device = excluded.device,
+			date = excluded.date,
+			notified = excluded.notified,
+			developer_comment = excluded.developer_comment,
+			replied_at = excluded.replied_at,
+			app_id = excluded.app_id,
+			sentiment = excluded.sentiment,
+			labels = excluded.labels
+	`, r.ReviewID, r.Rating, r.Text, r.Author, r.Device, r.Date.Format(time.RFC3339), r.Notified, r.DeveloperComment, repliedAt, r.AppID, r.Sentiment, strings.Join(r.Labels, ","))
+	if err != nil {
+		return fmt.Errorf("failed to upsert review: %v", err)
+	}
+	return nil
+}
+
+// List returns every stored review.
+func (s *SQLiteStore) List(ctx context.Context) ([]review.Review, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT review_id, rating, text, author, device, date, notified, developer_comment, replied_at, app_id, sentiment, labels FROM reviews`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %v", err)
+	}
+	defer rows.Close()
+
+	var out []review.Review
+	for rows.Next() {
+		r, err := scanReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan review: %v", err)
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// ListFiltered returns every stored review matching f, served from the
+// rating/date/notified indexes created in migrate().
+func (s *SQLiteStore) ListFiltered(ctx context.Context, f Filter) ([]review.Review, error) {
+	query := `SELECT review_id, rating, text, author, device, date, notified, developer_comment, replied_at, app_id, sentiment, labels FROM reviews WHERE 1=1`
+	var args []interface{}
+	if f.Rating != 0 {
+		query += ` AND rating = ?`
+		args = append(args, f.Rating)
+	}
+	if !f.Since.IsZero() {
+		query += ` AND date >= ?`
+		args = append(args, f.Since.Local().Format(time.RFC3339))
+	}
+	if !f.Until.IsZero() {
+		query += ` AND date <= ?`
+		args = append(args, f.Until.Local().Format(time.RFC3339))
+	}
+	if f.Notified != nil {
+		query += ` AND notified = ?`
+		args = append(args, *f.Notified)
+	}
+	for _, label := range f.Labels {
+		query += ` AND (',' || labels || ',') LIKE ?`
+		args = append(args, "%,"+label+",%")
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %v", err)
+	}
+	defer rows.Close()
+
+	var out []review.Review
+	for rows.Next() {
+		r, err := scanReview(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan review: %v", err)
+		}
+		out = append(out, r)
+	}
+	return out, rows.Err()
+}
+
+// MarkNotified marks reviewID as notified.
+func (s *SQLiteStore) MarkNotified(ctx context.Context, reviewID string) error {
+	_, err := s.db.ExecContext(ctx, `UPDATE reviews SET notified = 1 WHERE review_id = ?`, reviewID)
+	if err != nil {
+		return fmt.Errorf("failed to mark review notified: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+// Package store persists reviews and tracks their notification state across
+// CSV, SQLite, and Postgres backends.
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/wilik16/google-play-android-review-fetcher/review"
+)
+
+// ReviewStore persists reviews and tracks which have been notified.
+type ReviewStore interface {
+	// Get returns the review with the given ID, and whether it exists.
+	Get(ctx context.Context, reviewID string) (review.Review, bool, error)
+	// Upsert inserts a new review or updates an existing one by ReviewID.
+	Upsert(ctx context.Context, r review.Review) error
+	// List returns every stored review.
+	List(ctx context.Context) ([]review.Review, error)
+	// ListFiltered returns every stored review matching f. The SQLite and
+	// Postgres backends serve the rating/date/notified fields from the
+	// indexes created in migrate() and match Labels with a LIKE over the
+	// comma-joined labels column; CSVStore falls back to a linear scan.
+	ListFiltered(ctx context.Context, f Filter) ([]review.Review, error)
+	// MarkNotified marks a review as notified.
+	MarkNotified(ctx context.Context, reviewID string) error
+}
+
+// Filter narrows ListFiltered to reviews matching every non-zero field.
+type Filter struct {
+	// Rating, if non-zero, matches reviews with exactly this star rating.
+	Rating int
+	// Since, if non-zero, matches reviews dated on or after this time.
+	Since time.Time
+	// Until, if non-zero, matches reviews dated on or before this time.
+	Until time.Time
+	// Notified, if non-nil, matches reviews with this notified state.
+	Notified *bool
+	// Labels, if non-empty, matches reviews carrying every listed label
+	// (e.g. the classifier's "crash" label), so an operator can query "all
+	// crash reports last week" without reprocessing.
+	Labels []string
+}
+
+// match reports whether r satisfies every non-zero field of f. It backs
+// CSVStore.ListFiltered, which has no index to push the filter down to.
+func (f Filter) match(r review.Review) bool {
+	if f.Rating != 0 && r.Rating != f.Rating {
+		return false
+	}
+	if !f.Since.IsZero() && r.Date.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && r.Date.After(f.Until) {
+		return false
+	}
+	if f.Notified != nil && r.Notified != *f.Notified {
+		return false
+	}
+	for _, label := range f.Labels {
+		if !hasLabel(r.Labels, label) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasLabel reports whether labels contains label.
+func hasLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}